@@ -0,0 +1,118 @@
+// Command pulid generates and inspects PULIDs from the command line.
+//
+//	pulid gen -n 1000 -p US
+//	pulid parse <id>
+//	pulid inspect < ids.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/billitech/pulid"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "gen":
+		err = runGen(os.Args[2:])
+	case "parse":
+		err = runParse(os.Args[2:])
+	case "inspect":
+		err = runInspect(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pulid:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: pulid gen -n <count> -p <prefix> | parse <id> | inspect")
+}
+
+func runGen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	n := fs.Int("n", 1, "number of PULIDs to generate")
+	prefix := fs.String("p", "ID", "2-character prefix")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	g, err := pulid.NewGenerator(*prefix, nil, pulid.WithPooledEntropy())
+	if err != nil {
+		return err
+	}
+	defer g.Close()
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	_, err = g.WriteN(w, *n)
+	return err
+}
+
+func runParse(args []string) error {
+	fs := flag.NewFlagSet("parse", flag.ExitOnError)
+	utc := fs.Bool("utc", false, "print the timestamp in UTC instead of local time")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("parse: expected exactly one id argument")
+	}
+
+	return printInspection(fs.Arg(0), *utc, os.Stdout)
+}
+
+func runInspect(args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+	utc := fs.Bool("utc", false, "print timestamps in UTC instead of local time")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if err := printInspection(line, *utc, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "pulid:", err)
+			continue
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+	return scanner.Err()
+}
+
+func printInspection(s string, utc bool, w *os.File) error {
+	id, err := pulid.ParseStrict(s)
+	if err != nil {
+		return err
+	}
+
+	ts := id.Timestamp()
+	if !utc {
+		ts = ts.Local()
+	} else {
+		ts = ts.UTC()
+	}
+
+	fmt.Fprintf(w, "prefix: %s\ntime:   %s\nentropy: %x\n", id.Prefix(), ts.Format(time.RFC3339Nano), id.Entropy())
+	return nil
+}
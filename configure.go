@@ -0,0 +1,274 @@
+package pulid
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// BinaryMode controls how PULIDN encodes itself for MarshalBinary and Value.
+type BinaryMode int
+
+const (
+	// StandardBinary returns the full prefix+ULID byte layout, matching the
+	// historical PULID behaviour.
+	StandardBinary BinaryMode = iota
+
+	// UUIDCompatible returns only the 16-byte ULID portion from
+	// MarshalBinary/Value, so a PULIDN can be stored in a uuid/binary(16)
+	// column and round-tripped by drivers that expect that width. The
+	// prefix is preserved in the text form but dropped from the binary one.
+	UUIDCompatible
+)
+
+// MaxPrefixLen is the largest prefix length accepted by Configure.
+const MaxPrefixLen = 8
+
+var (
+	ErrInvalidPrefixLen = errors.New("pulid: prefix length must be between 0 and 8")
+
+	defaultPrefixLen  = 2
+	defaultBinaryMode = StandardBinary
+)
+
+// Configure sets the package-level defaults used by PULIDN when a prefix
+// length or binary mode isn't specified explicitly. It does not affect the
+// original PULID type, which remains fixed at a 2-byte prefix.
+//
+// ErrInvalidPrefixLen is returned if prefixLen is outside 0-8.
+func Configure(prefixLen int, mode BinaryMode) error {
+	if prefixLen < 0 || prefixLen > MaxPrefixLen {
+		return ErrInvalidPrefixLen
+	}
+
+	defaultPrefixLen = prefixLen
+	defaultBinaryMode = mode
+
+	return nil
+}
+
+// PULIDN is a variable-prefix-length PULID. Unlike PULID, whose prefix is
+// fixed at 2 bytes, PULIDN supports prefixes of 0-8 bytes encoded outside the
+// 16 ULID bytes, and an optional UUIDCompatible BinaryMode so the underlying
+// ULID can be stored in columns expecting a plain 16-byte UUID.
+type PULIDN struct {
+	data [MaxPrefixLen + 16]byte
+	plen uint8
+	mode BinaryMode
+}
+
+// NewN returns a PULIDN with the given prefix, Unix milliseconds timestamp
+// and entropy source, using the package's configured prefix length and
+// binary mode (see Configure).
+//
+// ErrPrefixLength is returned when len(prefix) doesn't match the configured
+// prefix length.
+func NewN(prefix string, ms uint64, entropy io.Reader) (PULIDN, error) {
+	return NewNWithMode(prefix, ms, entropy, defaultPrefixLen, defaultBinaryMode)
+}
+
+// NewNWithMode is like NewN but takes an explicit prefix length and binary
+// mode instead of the package defaults.
+func NewNWithMode(prefix string, ms uint64, entropy io.Reader, prefixLen int, mode BinaryMode) (PULIDN, error) {
+	id := PULIDN{plen: uint8(prefixLen), mode: mode}
+	if prefixLen < 0 || prefixLen > MaxPrefixLen || len(prefix) != prefixLen {
+		return PULIDN{}, ErrPrefixLength
+	}
+
+	u, err := ulid.New(ms, entropy)
+	if err != nil {
+		return PULIDN{}, err
+	}
+
+	copy(id.data[:prefixLen], prefix)
+	copy(id.data[prefixLen:prefixLen+16], u[:])
+
+	return id, nil
+}
+
+// MustNewN is a convenience function equivalent to NewN that panics on
+// failure instead of returning an error.
+func MustNewN(prefix string, ms uint64, entropy io.Reader) PULIDN {
+	id, err := NewN(prefix, ms, entropy)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Bytes returns the byte slice representation of the PULIDN, prefix
+// followed by the 16-byte ULID.
+func (id PULIDN) Bytes() []byte {
+	return id.data[:int(id.plen)+16]
+}
+
+// ULID returns the ULID encoded in the PULIDN.
+func (id PULIDN) ULID() ulid.ULID {
+	u := ulid.ULID{}
+	copy(u[:], id.data[id.plen:int(id.plen)+16])
+	return u
+}
+
+// Prefix returns the prefix of the PULIDN.
+func (id PULIDN) Prefix() string {
+	return string(id.data[:id.plen])
+}
+
+// String returns the lexicographically sortable, prefix+Crockford-base32
+// encoded form of the PULIDN.
+func (id PULIDN) String() string {
+	u := id.ULID()
+	text, _ := u.MarshalText()
+	return id.Prefix() + string(text)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. In
+// StandardBinary mode it returns prefix+ULID, matching PULID.MarshalBinary.
+// In UUIDCompatible mode it returns only the 16 ULID bytes, with the prefix
+// dropped, so the result can be stored in a plain uuid/binary(16) column.
+func (id PULIDN) MarshalBinary() ([]byte, error) {
+	if id.mode == UUIDCompatible {
+		u := id.ULID()
+		return u.MarshalBinary()
+	}
+
+	dst := make([]byte, len(id.Bytes()))
+	copy(dst, id.Bytes())
+	return dst, nil
+}
+
+// scanPrefixLen returns the prefix length UnmarshalBinary/UnmarshalText
+// should use: the receiver's own, if it was stamped by NewN, NewNWithMode,
+// FromUUID or NewScanTarget, or the package's configured default (see
+// Configure) for a bare zero-value receiver such as `var p PULIDN`.
+// StandardBinary is the zero value of BinaryMode, so this only kicks in for
+// receivers that haven't been told otherwise.
+func (id *PULIDN) scanPrefixLen() int {
+	if id.plen == 0 && id.mode == StandardBinary {
+		return defaultPrefixLen
+	}
+	return int(id.plen)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// accepts either a 16-byte plain ULID (UUIDCompatible layout) or a
+// prefix+ULID buffer matching the receiver's configured prefix length (see
+// scanPrefixLen). NewScanTarget stamps that length and mode explicitly;
+// without it, a zero-value receiver falls back to the package default.
+func (id *PULIDN) UnmarshalBinary(data []byte) error {
+	plen := id.scanPrefixLen()
+
+	switch len(data) {
+	case 16:
+		u := ulid.ULID{}
+		if err := u.UnmarshalBinary(data); err != nil {
+			return err
+		}
+		copy(id.data[plen:plen+16], u[:])
+		id.plen = uint8(plen)
+		return nil
+	case plen + 16:
+		copy(id.data[:plen+16], data)
+		id.plen = uint8(plen)
+		return nil
+	default:
+		return ulid.ErrDataSize
+	}
+}
+
+// Value implements the sql/driver.Valuer interface. In UUIDCompatible mode it
+// returns the 16 raw ULID bytes so the value can be written to a uuid
+// column; otherwise it returns the encoded text form, matching PULID.Value.
+func (id PULIDN) Value() (driver.Value, error) {
+	if id.mode == UUIDCompatible {
+		return id.MarshalBinary()
+	}
+	return id.String(), nil
+}
+
+// UnmarshalText parses the prefix+Crockford base32 text form of a PULIDN,
+// using the receiver's configured prefix length (see scanPrefixLen).
+// ErrDataSize is returned if len(v) doesn't match that prefix length plus
+// the 26-character ULID encoding.
+func (id *PULIDN) UnmarshalText(v []byte) error {
+	plen := id.scanPrefixLen()
+	if len(v) != plen+26 {
+		return ulid.ErrDataSize
+	}
+
+	u, err := ulid.ParseStrict(string(v[plen:]))
+	if err != nil {
+		return err
+	}
+
+	copy(id.data[:plen], v[:plen])
+	copy(id.data[plen:plen+16], u[:])
+	id.plen = uint8(plen)
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, accepting the same shapes
+// PULIDN can produce: a 16-byte UUIDCompatible binary value, a
+// prefix+ULID binary value, or a text-encoded string. This is what makes
+// the PULIDN stored via Value (e.g. in a uuid column) round-trip back
+// through database/sql.
+func (id *PULIDN) Scan(src interface{}) error {
+	switch x := src.(type) {
+	case nil:
+		return nil
+	case PULIDN:
+		*id = x
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(x))
+	case []byte:
+		return id.UnmarshalBinary(x)
+	}
+
+	return ulid.ErrScanValue
+}
+
+// NewScanTarget returns a PULIDN with no ULID yet, but with its prefix and
+// BinaryMode already stamped, so it can be passed to (*sql.Row).Scan and
+// round-trip correctly no matter what the package's configured defaults
+// are at scan time:
+//
+//	dst := pulid.NewScanTarget("US", pulid.StandardBinary)
+//	err := row.Scan(&dst)
+//
+// ErrPrefixLength is returned if len(prefix) > MaxPrefixLen.
+func NewScanTarget(prefix string, mode BinaryMode) (PULIDN, error) {
+	if len(prefix) > MaxPrefixLen {
+		return PULIDN{}, ErrPrefixLength
+	}
+
+	p := PULIDN{plen: uint8(len(prefix)), mode: mode}
+	copy(p.data[:len(prefix)], prefix)
+
+	return p, nil
+}
+
+// FromUUID builds a PULIDN from a prefix and a google/uuid.UUID, treating
+// the UUID's 16 bytes as the ULID portion verbatim.
+func FromUUID(prefix string, id uuid.UUID) (PULIDN, error) {
+	p := PULIDN{plen: uint8(len(prefix)), mode: UUIDCompatible}
+	if len(prefix) > MaxPrefixLen {
+		return PULIDN{}, ErrPrefixLength
+	}
+
+	copy(p.data[:len(prefix)], prefix)
+	copy(p.data[len(prefix):len(prefix)+16], id[:])
+
+	return p, nil
+}
+
+// ToUUID returns the 16 ULID bytes of the PULIDN as a google/uuid.UUID,
+// discarding the prefix.
+func (id PULIDN) ToUUID() uuid.UUID {
+	var u uuid.UUID
+	copy(u[:], id.data[id.plen:int(id.plen)+16])
+	return u
+}
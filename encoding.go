@@ -0,0 +1,189 @@
+package pulid
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Encoding converts between a prefix + 16-byte ULID pair and its text form.
+// Implementations decide the text layout (length, alphabet, any checksum)
+// used by MarshalTextTo/String and, via the length-based auto-detection in
+// Parse and ParseStrict, how an encoded PULID is read back.
+type Encoding interface {
+	// Encode writes the text encoding of prefix and ulidBytes (always 16
+	// bytes) to dst, which is sized exactly EncodedLen().
+	Encode(prefix, ulidBytes, dst []byte) error
+
+	// Decode reads src, writing the prefix into prefixDst (sized to the
+	// PULID's prefix length) and the ULID into ulidDst (always 16 bytes).
+	Decode(src, prefixDst, ulidDst []byte) error
+
+	// EncodedLen returns the exact text length this Encoding produces and
+	// expects, including the prefix.
+	EncodedLen() int
+}
+
+var (
+	// ErrChecksum is returned by CrockfordCheckEncoding.Decode when the
+	// trailing check symbol doesn't match the decoded ULID bytes.
+	ErrChecksum = errors.New("pulid: checksum mismatch")
+
+	// ErrUnknownEncoding is returned by Parse/ParseStrict when the input
+	// length doesn't match any registered Encoding.
+	ErrUnknownEncoding = errors.New("pulid: unrecognized encoding length")
+
+	defaultEncoding Encoding = CrockfordEncoding{}
+
+	encodingsByLen = map[int]Encoding{
+		EncodedSize:               CrockfordEncoding{},
+		CrockfordCheckEncodedSize: CrockfordCheckEncoding{},
+		HexEncodedSize:            HexEncoding{},
+	}
+)
+
+// SetDefaultEncoding sets the Encoding used by String and MarshalText.
+func SetDefaultEncoding(e Encoding) {
+	defaultEncoding = e
+}
+
+// RegisterEncoding makes e available for auto-detection by Parse and
+// ParseStrict when the input matches e.EncodedLen(). Registering an
+// encoding whose length collides with one already registered replaces it.
+func RegisterEncoding(e Encoding) {
+	encodingsByLen[e.EncodedLen()] = e
+}
+
+func encodingForLen(n int) (Encoding, bool) {
+	e, ok := encodingsByLen[n]
+	return e, ok
+}
+
+// CrockfordEncoding is the original 28-character form: a 2-byte prefix
+// followed by the standard 26-character Crockford base32 ULID encoding.
+type CrockfordEncoding struct{}
+
+func (CrockfordEncoding) EncodedLen() int { return EncodedSize }
+
+func (CrockfordEncoding) Encode(prefix, ulidBytes, dst []byte) error {
+	if len(dst) != EncodedSize {
+		return ulid.ErrBufferSize
+	}
+
+	u := ulid.ULID{}
+	copy(u[:], ulidBytes)
+
+	text, err := u.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	copy(dst, prefix)
+	copy(dst[len(prefix):], text)
+	return nil
+}
+
+func (CrockfordEncoding) Decode(src, prefixDst, ulidDst []byte) error {
+	if len(src) != EncodedSize {
+		return ulid.ErrDataSize
+	}
+
+	u, err := ulid.ParseStrict(string(src[len(prefixDst):]))
+	if err != nil {
+		return err
+	}
+
+	copy(prefixDst, src[:len(prefixDst)])
+	copy(ulidDst, u[:])
+	return nil
+}
+
+// CrockfordCheckEncodedSize is the text length produced by
+// CrockfordCheckEncoding: the standard 28-character form plus one check
+// symbol.
+const CrockfordCheckEncodedSize = EncodedSize + 1
+
+// crockfordCheckAlphabet holds the 37 symbols used for the Crockford check
+// symbol: the 32 standard encoding symbols (implicitly, values 0-31 map to
+// ulid.Encoding) followed by the 5 extra symbols used only for check values
+// 32-36.
+const crockfordCheckAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ*~$=U"
+
+// CrockfordCheckEncoding is CrockfordEncoding with a 29th trailing check
+// symbol computed over the 16 ULID bytes (treated as a base-32 number, mod
+// 37), so a single mistyped character is caught at parse time instead of
+// silently producing a different, valid-looking ULID.
+type CrockfordCheckEncoding struct{}
+
+func (CrockfordCheckEncoding) EncodedLen() int { return CrockfordCheckEncodedSize }
+
+func (CrockfordCheckEncoding) Encode(prefix, ulidBytes, dst []byte) error {
+	if len(dst) != CrockfordCheckEncodedSize {
+		return ulid.ErrBufferSize
+	}
+
+	if err := (CrockfordEncoding{}).Encode(prefix, ulidBytes, dst[:EncodedSize]); err != nil {
+		return err
+	}
+
+	dst[EncodedSize] = crockfordCheckAlphabet[checksum37(ulidBytes)]
+	return nil
+}
+
+func (CrockfordCheckEncoding) Decode(src, prefixDst, ulidDst []byte) error {
+	if len(src) != CrockfordCheckEncodedSize {
+		return ulid.ErrDataSize
+	}
+
+	if err := (CrockfordEncoding{}).Decode(src[:EncodedSize], prefixDst, ulidDst); err != nil {
+		return err
+	}
+
+	want := crockfordCheckAlphabet[checksum37(ulidDst)]
+	if src[EncodedSize] != want {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// checksum37 computes the Crockford check value (0-36) of a 16-byte ULID,
+// treating it as a big-endian base-256 number reduced mod 37.
+func checksum37(ulidBytes []byte) int {
+	rem := 0
+	for _, b := range ulidBytes {
+		rem = (rem*256 + int(b)) % 37
+	}
+	return rem
+}
+
+// HexEncodedSize is the text length produced by HexEncoding: the prefix
+// verbatim followed by 32 lowercase hex characters.
+const HexEncodedSize = 2 + 32
+
+// HexEncoding renders a PULID as its prefix followed by lowercase
+// hexadecimal, for logging and URL contexts where base32's ambiguous
+// characters (e.g. Crockford's ellision of I/L/O) are undesirable.
+type HexEncoding struct{}
+
+func (HexEncoding) EncodedLen() int { return HexEncodedSize }
+
+func (HexEncoding) Encode(prefix, ulidBytes, dst []byte) error {
+	if len(dst) != HexEncodedSize {
+		return ulid.ErrBufferSize
+	}
+
+	copy(dst, prefix)
+	hex.Encode(dst[len(prefix):], ulidBytes)
+	return nil
+}
+
+func (HexEncoding) Decode(src, prefixDst, ulidDst []byte) error {
+	if len(src) != HexEncodedSize {
+		return ulid.ErrDataSize
+	}
+
+	copy(prefixDst, src[:len(prefixDst)])
+	_, err := hex.Decode(ulidDst, src[len(prefixDst):])
+	return err
+}
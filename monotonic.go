@@ -0,0 +1,141 @@
+package pulid
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ErrMonotonicOverflow is returned by MonotonicEntropy.MonotonicRead when the
+// 80-bit entropy would wrap around within the same millisecond.
+var ErrMonotonicOverflow = errors.New("pulid: monotonic entropy overflow")
+
+// MonotonicEntropy is an io.Reader that increments its entropy for
+// successive ULIDs generated within the same millisecond, and additionally
+// refuses to let the observed timestamp move backwards: if ulid.Now()
+// returns a millisecond smaller than the last one it saw (e.g. due to clock
+// skew), it clamps to the previously observed millisecond and keeps
+// incrementing entropy instead of handing out an ID with an older
+// timestamp.
+//
+// It is not safe for concurrent use; use MakeMonotonic, which pools readers
+// internally, for concurrent generation.
+type MonotonicEntropy struct {
+	io.Reader
+	ms      uint64
+	inc     uint64
+	entropy uint80
+}
+
+// uint80 holds the 10 bytes (80 bits) of ULID entropy as a pair of uint64 and
+// uint16, allowing the low bits to be incremented without reallocating a
+// byte slice on every call.
+type uint80 struct {
+	hi uint16
+	lo uint64
+}
+
+// NewMonotonicEntropy returns a MonotonicEntropy that reads from rand to
+// seed its entropy and increases it by exactly inc for every ID requested
+// within the same millisecond. A zero inc defaults to 1.
+func NewMonotonicEntropy(rand io.Reader, inc uint64) *MonotonicEntropy {
+	if inc == 0 {
+		inc = 1
+	}
+
+	return &MonotonicEntropy{Reader: rand, inc: inc}
+}
+
+// ClampMS returns ms, or the last millisecond timestamp this reader has
+// produced entropy for, whichever is larger. Callers must pass the result
+// to ulid.New (not the raw, possibly backward-moving ms) for the
+// forward-time guarantee to hold: ulid.New bakes its argument into the
+// ULID's timestamp before MonotonicRead ever runs, so clamping inside
+// MonotonicRead alone cannot undo an already-set, too-old timestamp. See
+// NewMonotonic and MakeMonotonic, which do this for the caller.
+func (m *MonotonicEntropy) ClampMS(ms uint64) uint64 {
+	if ms < m.ms {
+		return m.ms
+	}
+	return ms
+}
+
+// MonotonicRead implements ulid.MonotonicReader. It is called by ulid.New
+// with the millisecond timestamp of the ID being generated; callers must
+// have already clamped that timestamp with ClampMS (ulid.New itself sets
+// the ULID's time field before calling MonotonicRead, so this method can
+// only manage entropy, not the timestamp).
+func (m *MonotonicEntropy) MonotonicRead(ms uint64, p []byte) error {
+	if ms < m.ms {
+		// Should not happen when ms was produced by ClampMS, but guard
+		// against direct misuse rather than regress the entropy sequence.
+		ms = m.ms
+	}
+
+	if ms > m.ms {
+		m.ms = ms
+		if _, err := io.ReadFull(m.Reader, p); err != nil {
+			return err
+		}
+		m.entropy.hi = binary.BigEndian.Uint16(p[:2])
+		m.entropy.lo = binary.BigEndian.Uint64(p[2:])
+		return nil
+	}
+
+	if err := m.increment(); err != nil {
+		return err
+	}
+
+	binary.BigEndian.PutUint16(p[:2], m.entropy.hi)
+	binary.BigEndian.PutUint64(p[2:], m.entropy.lo)
+	return nil
+}
+
+func (m *MonotonicEntropy) increment() error {
+	lo := m.entropy.lo + m.inc
+	hi := m.entropy.hi
+	if lo < m.entropy.lo {
+		hi++
+	}
+	if hi < m.entropy.hi {
+		return ErrMonotonicOverflow
+	}
+
+	m.entropy.hi, m.entropy.lo = hi, lo
+	return nil
+}
+
+var monotonicPool = sync.Pool{
+	New: func() interface{} {
+		return NewMonotonicEntropy(ulid.DefaultEntropy(), 0)
+	},
+}
+
+// NewMonotonic returns a PULID using m for entropy, with the current time
+// in Unix milliseconds clamped forward past the last millisecond m has
+// produced an ID for, so a backward clock jump can never yield an older
+// ID than one already generated through m.
+func NewMonotonic(prefix string, m *MonotonicEntropy) (PULID, error) {
+	return New(prefix, m.ClampMS(ulid.Now()), m)
+}
+
+// MakeMonotonic returns a PULID with the current time in Unix milliseconds
+// and strictly monotonic, forward-only entropy, drawing a MonotonicEntropy
+// from a package-level pool so concurrent generation stays lock-light. The
+// timestamp itself is also clamped forward (see NewMonotonic), so a
+// backward clock jump cannot produce an ID older than one already
+// generated through the same pooled reader.
+// Panics if prefix is not of length 2.
+func MakeMonotonic(prefix string) PULID {
+	m := monotonicPool.Get().(*MonotonicEntropy)
+	defer monotonicPool.Put(m)
+
+	id, err := NewMonotonic(prefix, m)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
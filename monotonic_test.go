@@ -0,0 +1,52 @@
+package pulid
+
+import (
+	"bytes"
+	"testing"
+)
+
+// seedReader is a deterministic io.Reader used to seed MonotonicEntropy in
+// tests, avoiding dependence on crypto/rand output.
+func seedReader() *bytes.Reader {
+	return bytes.NewReader(bytes.Repeat([]byte{0x01}, 10))
+}
+
+func TestMonotonicEntropyClockSkewBackward(t *testing.T) {
+	m := NewMonotonicEntropy(seedReader(), 1)
+
+	first, err := New("US", m.ClampMS(10_000), m)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// Clock jumps backward by 3 seconds.
+	backwardMS := m.ClampMS(7_000)
+	if backwardMS != 10_000 {
+		t.Fatalf("ClampMS did not hold the line: got %d, want 10000", backwardMS)
+	}
+
+	second, err := New("US", backwardMS, m)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if second.Time() < first.Time() {
+		t.Fatalf("second id's time %d is older than first id's time %d after a backward clock jump", second.Time(), first.Time())
+	}
+
+	if second.Compare(first) <= 0 {
+		t.Fatalf("second id %s did not sort after first id %s", second, first)
+	}
+}
+
+func TestMonotonicEntropyClampMSForward(t *testing.T) {
+	m := NewMonotonicEntropy(seedReader(), 1)
+
+	if got := m.ClampMS(5_000); got != 5_000 {
+		t.Fatalf("ClampMS(5000) = %d, want 5000 on a fresh reader", got)
+	}
+
+	if got := m.ClampMS(5_500); got != 5_500 {
+		t.Fatalf("ClampMS(5500) = %d, want 5500 when moving forward", got)
+	}
+}
@@ -0,0 +1,92 @@
+package pulid
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ErrUnknownPrefix is returned by ParseLenient when the id's prefix hasn't
+// been registered with RegisterPrefix, and at least one prefix has been
+// registered (an empty registry disables the check).
+var ErrUnknownPrefix = errors.New("pulid: unknown prefix")
+
+var (
+	prefixRegistryMu sync.RWMutex
+	prefixRegistry   = map[string]string{}
+)
+
+// RegisterPrefix associates prefix with typeName, so applications can
+// enumerate the entity prefixes they use (LookupPrefix) and so
+// ParseLenient can reject ids whose prefix is unrecognized. It is safe for
+// concurrent use, and is typically called from init().
+func RegisterPrefix(prefix string, typeName string) {
+	prefixRegistryMu.Lock()
+	defer prefixRegistryMu.Unlock()
+	prefixRegistry[prefix] = typeName
+}
+
+// LookupPrefix returns the type name registered for prefix, and whether one
+// was found.
+func LookupPrefix(prefix string) (typeName string, ok bool) {
+	prefixRegistryMu.RLock()
+	defer prefixRegistryMu.RUnlock()
+	typeName, ok = prefixRegistry[prefix]
+	return typeName, ok
+}
+
+// ParseLenient parses an encoded PULID the way the Crockford base32
+// alphabet is documented to work: case-insensitively, and folding the
+// ambiguous characters I and L to 1, and O to 0, within the ULID portion.
+// The prefix is uppercased but not otherwise normalized.
+//
+// If any prefix has been registered with RegisterPrefix, the id's prefix is
+// validated against the registry and ErrUnknownPrefix is returned if it
+// isn't found. With an empty registry, any prefix is accepted.
+//
+// ErrDataSize is returned if len(id) != EncodedSize.
+func ParseLenient(id string) (PULID, error) {
+	var pulid PULID
+
+	if len(id) != EncodedSize {
+		return pulid, ulid.ErrDataSize
+	}
+
+	norm := bytes.ToUpper([]byte(id))
+	prefix := norm[:2]
+
+	prefixRegistryMu.RLock()
+	registered := len(prefixRegistry) > 0
+	prefixRegistryMu.RUnlock()
+
+	if registered {
+		if _, ok := LookupPrefix(string(prefix)); !ok {
+			return pulid, ErrUnknownPrefix
+		}
+	}
+
+	foldAmbiguousCrockford(norm[2:])
+
+	u, err := ulid.ParseStrict(string(norm[2:]))
+	if err != nil {
+		return pulid, err
+	}
+
+	join(prefix, u, &pulid)
+	return pulid, nil
+}
+
+// foldAmbiguousCrockford rewrites Crockford's ambiguous characters in place:
+// I and L fold to 1, O folds to 0. b is assumed already uppercased.
+func foldAmbiguousCrockford(b []byte) {
+	for i, c := range b {
+		switch c {
+		case 'I', 'L':
+			b[i] = '1'
+		case 'O':
+			b[i] = '0'
+		}
+	}
+}
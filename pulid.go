@@ -77,52 +77,62 @@ func Make(prefix string) PULID {
 
 // Parse parses an encoded PULID, returning an error in case of failure.
 //
-// ErrDataSize is returned if the len(ulid) is different from an encoded
-// ULID's length. Invalid encodings produce undefined ULIDs. For a version that
-// returns an error instead, see ParseStrict.
+// The standard 28-character Crockford form is parsed leniently, matching
+// oklog/ulid's UnmarshalText: invalid encodings within a correctly-sized id
+// produce undefined ULIDs. For a version that returns an error instead, see
+// ParseStrict.
+//
+// Ids of any other registered Encoding's length (see RegisterEncoding) are
+// auto-detected and decoded strictly, since those encodings have no lenient
+// form. ErrUnknownEncoding is returned if len(id) matches no registered
+// Encoding's EncodedLen.
 func Parse(id string) (PULID, error) {
 	pulid := PULID{}
 	return pulid, parseBytes([]byte(id), &pulid)
 }
 
-// ParseStrict parses an encoded PULID, returning an error in case of failure.
+// ParseStrict parses an encoded PULID, returning an error in case of
+// failure.
 //
-// It is like Parse, but additionally validates that the parsed ULID consists
-// only of valid base32 characters. It is slightly slower than Parse.
+// It is like Parse, but additionally validates that the parsed ULID
+// consists only of valid characters for its encoding. It is slightly
+// slower than Parse.
 //
-// ErrDataSize is returned if the len(ulid) is different from an encoded
-// ULID's length. Invalid encodings return ErrInvalidCharacters.
+// The encoding is auto-detected from len(id): ErrUnknownEncoding is
+// returned if it doesn't match any registered Encoding's EncodedLen.
 func ParseStrict(id string) (PULID, error) {
 	pulid := PULID{}
-	if len(id) != EncodedSize {
-		return pulid, ulid.ErrDataSize
-	}
+	return pulid, parseStrictBytes([]byte(id), &pulid)
+}
 
-	ulid, err := ulid.ParseStrict(id[2:])
+func parseBytes(v []byte, id *PULID) error {
+	if len(v) == EncodedSize {
+		ulid := ulid.ULID{}
+		if err := ulid.UnmarshalText(v[2:]); err != nil {
+			return err
+		}
 
-	if err != nil {
-		return pulid, err
+		join(v[:2], ulid, id)
+		return nil
 	}
 
-	join([]byte(id[:2]), ulid, &pulid)
-
-	return pulid, nil
+	return parseStrictBytes(v, id)
 }
 
-func parseBytes(v []byte, id *PULID) error {
-	if len(v) != EncodedSize {
-		return ulid.ErrDataSize
+func parseStrictBytes(v []byte, id *PULID) error {
+	enc, ok := encodingForLen(len(v))
+	if !ok {
+		return ErrUnknownEncoding
 	}
 
-	ulid := ulid.ULID{}
-	err := ulid.UnmarshalText(v[2:])
-
-	if err != nil {
+	var prefix [2]byte
+	var ulidBytes [16]byte
+	if err := enc.Decode(v, prefix[:], ulidBytes[:]); err != nil {
 		return err
 	}
 
-	join(v[:2], ulid, id)
-
+	copy(id[:2], prefix[:])
+	copy(id[2:], ulidBytes[:])
 	return nil
 }
 
@@ -168,12 +178,12 @@ func (id PULID) Prefix() string {
 	return string(id.PrefixBytes())
 }
 
-// String returns a lexicographically sortable string encoded PULID
-// (26 characters, non-standard base 32) e.g. PR01AN4Z07BY79KA1307SR9X4MV3.
+// String returns the PULID encoded using the package's default Encoding
+// (see SetDefaultEncoding), which defaults to the original 28-character
+// prefix+Crockford base32 form, e.g. PR01AN4Z07BY79KA1307SR9X4MV3.
 // Format: pptttttttttteeeeeeeeeeeeeeee where p is prefix t is time and e is entropy.
 func (id PULID) String() string {
-	pulid := make([]byte, EncodedSize)
-	_ = id.MarshalTextTo(pulid)
+	pulid, _ := id.MarshalText()
 	return string(pulid)
 }
 
@@ -207,36 +217,31 @@ func (id *PULID) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
-// MarshalText implements the encoding.TextMarshaler interface by
-// returning the string encoded ULID.
+// MarshalText implements the encoding.TextMarshaler interface by encoding
+// the PULID using the package's default Encoding (see SetDefaultEncoding),
+// the same one used by String and honored by Value/UnmarshalGQL. Keeping
+// all three in step means a PULID doesn't serialize one way over JSON and
+// another over the database or GraphQL.
 func (id PULID) MarshalText() ([]byte, error) {
-	ulid := make([]byte, EncodedSize)
-	return ulid, id.MarshalTextTo(ulid)
+	dst := make([]byte, defaultEncoding.EncodedLen())
+	return dst, id.MarshalTextTo(dst)
 }
 
-// MarshalTextTo writes the ULID as a string to the given buffer.
-// ErrBufferSize is returned when the len(dst) != 26.
+// MarshalTextTo writes the PULID as text to the given buffer, using the
+// package's default Encoding. ErrBufferSize is returned when len(dst) !=
+// that Encoding's EncodedLen().
 func (id PULID) MarshalTextTo(dst []byte) error {
 	if id == Nil {
+		if len(dst) != defaultEncoding.EncodedLen() {
+			return ulid.ErrBufferSize
+		}
 		for i := range dst {
 			dst[i] = 48
 		}
 		return nil
 	}
 
-	ulidBytes, err := id.ULID().MarshalText()
-	if err != nil {
-		return err
-	}
-
-	prefixBytes := id.PrefixBytes()
-	copy(dst, prefixBytes)
-
-	for i := range ulidBytes {
-		dst[i+len(prefixBytes)] = ulidBytes[i]
-	}
-
-	return nil
+	return defaultEncoding.Encode(id.PrefixBytes(), id[2:], dst)
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface by